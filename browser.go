@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// openBrowser opens url in the user's default browser. Failures are
+// non-fatal; callers should fall back to printing the URL.
+func openBrowser(url string) error {
+	var cmd string
+	var args []string
+
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = "open"
+		args = []string{url}
+	case "windows":
+		cmd = "rundll32"
+		args = []string{"url.dll,FileProtocolHandler", url}
+	default:
+		cmd = "xdg-open"
+		args = []string{url}
+	}
+
+	if err := exec.Command(cmd, args...).Start(); err != nil {
+		return fmt.Errorf("opening browser: %w", err)
+	}
+	return nil
+}
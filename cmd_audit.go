@@ -0,0 +1,272 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	gogitlab "github.com/xanzy/go-gitlab"
+
+	"github.com/danfinn/glu/internal/audit"
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Run periodic access reviews: inactive, orphaned or admin accounts",
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+}
+
+// latestActivity returns the most recent timestamp GitLab reports for a
+// user's sign-in/activity, or the zero time if it has none.
+func latestActivity(u *gogitlab.User) time.Time {
+	var latest time.Time
+	consider := func(t time.Time) {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+	if u.LastSignInAt != nil {
+		consider(*u.LastSignInAt)
+	}
+	if u.CurrentSignInAt != nil {
+		consider(*u.CurrentSignInAt)
+	}
+	if u.LastActivityOn != nil {
+		consider(time.Time(*u.LastActivityOn))
+	}
+	return latest
+}
+
+func writeAuditCSV(path string, rows [][]string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("audit: %w", err)
+	}
+	defer f.Close()
+	w := csv.NewWriter(f)
+	w.Write([]string{"id", "username", "email", "last_activity", "reason"})
+	for _, row := range rows {
+		w.Write(row)
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// ----------------------------------------------------------------- inactive
+
+var (
+	inactiveDays          int
+	inactiveBlock         bool
+	inactiveYes           bool
+	inactiveCSV           string
+	inactiveExcludeAdmins bool
+	inactiveExcludeGroups string
+)
+
+var auditInactiveCmd = &cobra.Command{
+	Use:   "inactive",
+	Short: "Flag (and optionally block) accounts inactive for --days",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := loadClient()
+		if err != nil {
+			return err
+		}
+		users, err := client.GetAllUsers()
+		if err != nil {
+			return err
+		}
+
+		excluded := map[int]bool{}
+		if inactiveExcludeGroups != "" {
+			for _, g := range strings.Split(inactiveExcludeGroups, ",") {
+				ids, err := client.GroupMemberIDs(strings.TrimSpace(g))
+				if err != nil {
+					return err
+				}
+				for _, id := range ids {
+					excluded[id] = true
+				}
+			}
+		}
+
+		threshold := time.Now().AddDate(0, 0, -inactiveDays)
+		var rows [][]string
+		var matches []*gogitlab.User
+		for _, u := range users {
+			if inactiveExcludeAdmins && u.IsAdmin {
+				continue
+			}
+			if excluded[u.ID] {
+				continue
+			}
+
+			last := latestActivity(u)
+			var reason string
+			switch {
+			case last.IsZero() && u.CreatedAt != nil && u.CreatedAt.Before(threshold):
+				reason = "never signed in"
+			case !last.IsZero() && last.Before(threshold):
+				reason = "inactive since " + last.Format("2006-01-02")
+			default:
+				continue
+			}
+
+			matches = append(matches, u)
+			rows = append(rows, []string{strconv.Itoa(u.ID), u.Username, u.Email, last.Format("2006-01-02"), reason})
+		}
+
+		fmt.Printf("%d account(s) inactive for more than %d days:\n", len(matches), inactiveDays)
+		for _, row := range rows {
+			fmt.Printf("  %-8s %-20s %-30s %s\n", row[0], row[1], row[2], row[4])
+		}
+
+		if inactiveCSV != "" {
+			if err := writeAuditCSV(inactiveCSV, rows); err != nil {
+				return err
+			}
+			fmt.Println("Wrote report to", inactiveCSV)
+		}
+
+		if !inactiveBlock {
+			return nil
+		}
+		if !inactiveYes {
+			fmt.Println("Pass --yes to actually block the accounts listed above.")
+			return nil
+		}
+
+		for _, u := range matches {
+			if err := client.BlockUser(u.ID); err != nil {
+				fmt.Printf("failed to block %s (id %d): %v\n", u.Username, u.ID, err)
+				continue
+			}
+			if err := audit.Append(audit.Entry{
+				Time:     time.Now(),
+				Action:   "block",
+				UserID:   u.ID,
+				Username: u.Username,
+				Reason:   fmt.Sprintf("glu audit inactive --days=%d", inactiveDays),
+			}); err != nil {
+				fmt.Printf("warning: failed to write audit log entry for %s (id %d): %v\n", u.Username, u.ID, err)
+			}
+			fmt.Printf("blocked %s (id %d)\n", u.Username, u.ID)
+		}
+		return nil
+	},
+}
+
+func init() {
+	auditInactiveCmd.Flags().IntVar(&inactiveDays, "days", 90, "flag accounts inactive for at least this many days")
+	auditInactiveCmd.Flags().BoolVar(&inactiveBlock, "block", false, "block every matched account")
+	auditInactiveCmd.Flags().BoolVar(&inactiveYes, "yes", false, "required alongside --block to actually mutate accounts")
+	auditInactiveCmd.Flags().StringVar(&inactiveCSV, "csv", "", "also write the report to this CSV file")
+	auditInactiveCmd.Flags().BoolVar(&inactiveExcludeAdmins, "exclude-admins", false, "never flag admin accounts")
+	auditInactiveCmd.Flags().StringVar(&inactiveExcludeGroups, "exclude-groups", "", "comma-separated group paths whose members are never flagged")
+	auditCmd.AddCommand(auditInactiveCmd)
+}
+
+// ------------------------------------------------------------------ orphans
+
+var (
+	orphanDays int
+	orphanCSV  string
+)
+
+var auditOrphansCmd = &cobra.Command{
+	Use:   "orphans",
+	Short: "List unconfirmed accounts older than --days",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := loadClient()
+		if err != nil {
+			return err
+		}
+		users, err := client.GetAllUsers()
+		if err != nil {
+			return err
+		}
+
+		threshold := time.Now().AddDate(0, 0, -orphanDays)
+		var rows [][]string
+		for _, u := range users {
+			if u.ConfirmedAt != nil {
+				continue
+			}
+			if u.CreatedAt == nil || !u.CreatedAt.Before(threshold) {
+				continue
+			}
+			rows = append(rows, []string{strconv.Itoa(u.ID), u.Username, u.Email, u.CreatedAt.Format("2006-01-02"), "unconfirmed"})
+		}
+
+		fmt.Printf("%d unconfirmed account(s) older than %d days:\n", len(rows), orphanDays)
+		for _, row := range rows {
+			fmt.Printf("  %-8s %-20s %-30s created %s\n", row[0], row[1], row[2], row[3])
+		}
+
+		if orphanCSV != "" {
+			if err := writeAuditCSV(orphanCSV, rows); err != nil {
+				return err
+			}
+			fmt.Println("Wrote report to", orphanCSV)
+		}
+		return nil
+	},
+}
+
+func init() {
+	auditOrphansCmd.Flags().IntVar(&orphanDays, "days", 30, "flag unconfirmed accounts older than this many days")
+	auditOrphansCmd.Flags().StringVar(&orphanCSV, "csv", "", "also write the report to this CSV file")
+	auditCmd.AddCommand(auditOrphansCmd)
+}
+
+// ------------------------------------------------------------------- admins
+
+var adminsCSV string
+
+var auditAdminsCmd = &cobra.Command{
+	Use:   "admins",
+	Short: "List every account with is_admin=true",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := loadClient()
+		if err != nil {
+			return err
+		}
+		users, err := client.GetAllUsers()
+		if err != nil {
+			return err
+		}
+
+		var rows [][]string
+		for _, u := range users {
+			if !u.IsAdmin {
+				continue
+			}
+			rows = append(rows, []string{strconv.Itoa(u.ID), u.Username, u.Email, "", "is_admin"})
+		}
+
+		fmt.Printf("%d admin account(s):\n", len(rows))
+		for _, row := range rows {
+			fmt.Printf("  %-8s %-20s %s\n", row[0], row[1], row[2])
+		}
+
+		if adminsCSV != "" {
+			if err := writeAuditCSV(adminsCSV, rows); err != nil {
+				return err
+			}
+			fmt.Println("Wrote report to", adminsCSV)
+		}
+		return nil
+	},
+}
+
+func init() {
+	auditAdminsCmd.Flags().StringVar(&adminsCSV, "csv", "", "also write the report to this CSV file")
+	auditCmd.AddCommand(auditAdminsCmd)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/danfinn/glu/internal/gitlab"
+)
+
+var createCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new GitLab user",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := loadClient()
+		if err != nil {
+			return err
+		}
+		return createUser(client)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(createCmd)
+}
+
+// createUser takes input from the console and creates an account in Gitlab.
+// The minimum information to create an account is Name, Username and Email.
+func createUser(c *gitlab.Client) error {
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Print("Name: ")
+	inputName, _ := reader.ReadString('\n')
+	inputName = strings.Replace(inputName, "\n", "", -1)
+
+	fmt.Print("Email: ")
+	inputEmail, _ := reader.ReadString('\n')
+	inputEmail = strings.Replace(inputEmail, "\n", "", -1)
+
+	fmt.Print("Username: ")
+	inputUserName, _ := reader.ReadString('\n')
+	inputUserName = strings.Replace(inputUserName, "\n", "", -1)
+
+	// check that inputUserName has no whitespace
+	var isAlpha = regexp.MustCompile(`^[A-Za-z]+$`)
+	if !isAlpha.MatchString(inputUserName) {
+		return fmt.Errorf("error: %v is not a valid username", inputUserName)
+	}
+
+	// check that inputEmail is valid
+	// taken from https://www.alexedwards.net/blog/validation-snippets-for-go#required-inputs
+	var rxEmail = regexp.MustCompile("^[a-zA-Z0-9.!#$%&'*+\\/=?^_`{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$")
+	if len(inputEmail) > 254 || !rxEmail.MatchString(inputEmail) {
+		return fmt.Errorf("error: %v is not a valid email address", inputEmail)
+	}
+
+	u, err := c.CreateUser(gitlab.CreateUserOptions{
+		Name:          inputName,
+		Username:      inputUserName,
+		Email:         inputEmail,
+		ResetPassword: true,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Created user %v (id %v)\n", u.Username, u.ID)
+	return nil
+}
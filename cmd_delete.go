@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var deleteHard bool
+
+var deleteCmd = &cobra.Command{
+	Use:   "delete <id>",
+	Short: "Delete a user",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := userID(args)
+		if err != nil {
+			return err
+		}
+		client, err := loadClient()
+		if err != nil {
+			return err
+		}
+		if err := client.DeleteUser(id, deleteHard); err != nil {
+			return err
+		}
+		fmt.Printf("UserID %v has been deleted\n", id)
+		return nil
+	},
+}
+
+func init() {
+	deleteCmd.Flags().BoolVar(&deleteHard, "hard", false, "also delete content owned by the user instead of reassigning it to a ghost user")
+	rootCmd.AddCommand(deleteCmd)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/danfinn/glu/internal/userio"
+)
+
+var (
+	exportFormat string
+	exportFields string
+	exportOutput string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export all users as CSV or JSON",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := loadClient()
+		if err != nil {
+			return err
+		}
+		users, err := client.GetAllUsers()
+		if err != nil {
+			return err
+		}
+		records := make([]userio.Record, len(users))
+		for i, u := range users {
+			records[i] = userio.FromUser(u)
+		}
+
+		out := os.Stdout
+		if exportOutput != "" {
+			f, err := os.Create(exportOutput)
+			if err != nil {
+				return fmt.Errorf("export: %w", err)
+			}
+			defer f.Close()
+			out = f
+		}
+
+		fields := strings.Split(exportFields, ",")
+		switch exportFormat {
+		case "csv":
+			return userio.WriteCSV(out, records, fields)
+		case "json":
+			return userio.WriteJSON(out, records, fields)
+		default:
+			return fmt.Errorf("export: --format must be csv or json, got %q", exportFormat)
+		}
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringVar(&exportFormat, "format", "csv", "output format: csv or json")
+	exportCmd.Flags().StringVar(&exportFields, "fields", strings.Join(userio.Fields, ","), "comma-separated list of fields to include")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "write to this file instead of stdout")
+	rootCmd.AddCommand(exportCmd)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	impersonationName      string
+	impersonationScopes    []string
+	impersonationExpiresAt string
+)
+
+var impersonationTokenCmd = &cobra.Command{
+	Use:   "impersonation-token <id>",
+	Short: "Create an impersonation token for a user",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := userID(args)
+		if err != nil {
+			return err
+		}
+
+		var expiresAt *time.Time
+		if impersonationExpiresAt != "" {
+			t, err := time.Parse("2006-01-02", impersonationExpiresAt)
+			if err != nil {
+				return fmt.Errorf("--expires-at must be in YYYY-MM-DD format: %w", err)
+			}
+			expiresAt = &t
+		}
+
+		client, err := loadClient()
+		if err != nil {
+			return err
+		}
+		token, err := client.CreateImpersonationToken(id, impersonationName, impersonationScopes, expiresAt)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Created impersonation token %q for user %v: %v\n", token.Name, id, token.Token)
+		return nil
+	},
+}
+
+func init() {
+	impersonationTokenCmd.Flags().StringVar(&impersonationName, "name", "", "token name")
+	impersonationTokenCmd.Flags().StringSliceVar(&impersonationScopes, "scopes", []string{"api"}, "comma-separated list of scopes")
+	impersonationTokenCmd.Flags().StringVar(&impersonationExpiresAt, "expires-at", "", "expiration date (YYYY-MM-DD)")
+	impersonationTokenCmd.MarkFlagRequired("name")
+	rootCmd.AddCommand(impersonationTokenCmd)
+}
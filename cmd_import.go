@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	gogitlab "github.com/xanzy/go-gitlab"
+
+	"github.com/danfinn/glu/internal/gitlab"
+	"github.com/danfinn/glu/internal/userio"
+)
+
+var (
+	importFormat        string
+	importFile          string
+	importMapFile       string
+	importDryRun        bool
+	importResetPassword bool
+	importSendInvite    bool
+	importUpdate        bool
+	importQPS           float64
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Bulk-create (or update) users from a CSV or JSON file",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := os.Open(importFile)
+		if err != nil {
+			return fmt.Errorf("import: %w", err)
+		}
+		defer f.Close()
+
+		var rows []map[string]string
+		switch importFormat {
+		case "csv":
+			rows, err = userio.ReadCSV(f)
+		case "json":
+			rows, err = userio.ReadJSON(f)
+		default:
+			return fmt.Errorf("import: --format must be csv or json, got %q", importFormat)
+		}
+		if err != nil {
+			return err
+		}
+
+		if importMapFile != "" {
+			mapping, err := userio.LoadMapping(importMapFile)
+			if err != nil {
+				return err
+			}
+			rows = userio.ApplyMapping(rows, mapping)
+		}
+
+		client, err := loadClient()
+		if err != nil {
+			return err
+		}
+		existing, err := client.GetAllUsers()
+		if err != nil {
+			return err
+		}
+		byUsername := make(map[string]*gogitlab.User, len(existing))
+		byEmail := make(map[string]*gogitlab.User, len(existing))
+		for _, u := range existing {
+			byUsername[u.Username] = u
+			byEmail[u.Email] = u
+		}
+
+		var limiter *time.Ticker
+		if importQPS > 0 {
+			limiter = time.NewTicker(time.Duration(float64(time.Second) / importQPS))
+			defer limiter.Stop()
+		}
+
+		var created, updated, skipped, failed int
+		for i, row := range rows {
+			username := row["username"]
+			email := row["email"]
+			name := row["name"]
+			if username == "" || email == "" {
+				fmt.Printf("row %d: skipped, missing username or email\n", i+1)
+				skipped++
+				continue
+			}
+
+			match := byUsername[username]
+			if match == nil {
+				match = byEmail[email]
+			}
+
+			if match != nil && !importUpdate {
+				fmt.Printf("row %d: skipped, %s already exists (id %d)\n", i+1, username, match.ID)
+				skipped++
+				continue
+			}
+
+			if importDryRun {
+				if match != nil {
+					fmt.Printf("row %d: would update %s (id %d)\n", i+1, username, match.ID)
+				} else {
+					fmt.Printf("row %d: would create %s\n", i+1, username)
+				}
+				continue
+			}
+
+			if limiter != nil {
+				<-limiter.C
+			}
+
+			if match != nil {
+				var opts gitlab.ModifyUserOptions
+				if name != "" && name != match.Name {
+					opts.Name = &name
+				}
+				if email != match.Email {
+					opts.Email = &email
+				}
+				if _, err := client.ModifyUser(match.ID, opts); err != nil {
+					fmt.Printf("row %d: failed to update %s: %v\n", i+1, username, err)
+					failed++
+					continue
+				}
+				updated++
+				continue
+			}
+
+			if _, err := client.CreateUser(gitlab.CreateUserOptions{
+				Name:             name,
+				Username:         username,
+				Email:            email,
+				ResetPassword:    importResetPassword,
+				SkipConfirmation: !importSendInvite,
+			}); err != nil {
+				fmt.Printf("row %d: failed to create %s: %v\n", i+1, username, err)
+				failed++
+				continue
+			}
+			created++
+		}
+
+		fmt.Printf("\ncreated: %d, updated: %d, skipped: %d, failed: %d\n", created, updated, skipped, failed)
+		if failed > 0 {
+			return fmt.Errorf("import: %d row(s) failed", failed)
+		}
+		return nil
+	},
+}
+
+func init() {
+	importCmd.Flags().StringVar(&importFormat, "format", "csv", "input format: csv or json")
+	importCmd.Flags().StringVar(&importFile, "file", "", "path to the file to import")
+	importCmd.Flags().StringVar(&importMapFile, "map", "", "JSON file mapping source column headers to glu fields")
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "report what would happen without making changes")
+	importCmd.Flags().BoolVar(&importResetPassword, "reset-password", false, "send created users a set-password email")
+	importCmd.Flags().BoolVar(&importSendInvite, "send-invite", false, "send created users a confirmation/invite email")
+	importCmd.Flags().BoolVar(&importUpdate, "update", false, "update existing users instead of skipping them")
+	importCmd.Flags().Float64Var(&importQPS, "qps", 5, "maximum requests per second sent to GitLab")
+	importCmd.MarkFlagRequired("file")
+	rootCmd.AddCommand(importCmd)
+}
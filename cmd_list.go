@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+var listActiveOnly bool
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List GitLab users",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := loadClient()
+		if err != nil {
+			return err
+		}
+		users, err := client.GetUsers(listActiveOnly)
+		if err != nil {
+			return err
+		}
+		sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+		for _, u := range users {
+			fmt.Println("-----------------------")
+			fmt.Println("ID :", u.ID)
+			fmt.Println("Name :", u.Name)
+		}
+		return nil
+	},
+}
+
+func init() {
+	listCmd.Flags().BoolVarP(&listActiveOnly, "active", "a", true, "limit to active users")
+	rootCmd.AddCommand(listCmd)
+}
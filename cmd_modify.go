@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/danfinn/glu/internal/gitlab"
+)
+
+var (
+	modifyAdmin    bool
+	modifyExternal bool
+	modifyEmail    string
+	modifyName     string
+)
+
+var modifyCmd = &cobra.Command{
+	Use:   "modify <id>",
+	Short: "Change a user's admin/external flag, email or name",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := userID(args)
+		if err != nil {
+			return err
+		}
+		client, err := loadClient()
+		if err != nil {
+			return err
+		}
+
+		var opts gitlab.ModifyUserOptions
+		if cmd.Flags().Changed("admin") {
+			opts.Admin = &modifyAdmin
+		}
+		if cmd.Flags().Changed("external") {
+			opts.External = &modifyExternal
+		}
+		if cmd.Flags().Changed("email") {
+			opts.Email = &modifyEmail
+		}
+		if cmd.Flags().Changed("name") {
+			opts.Name = &modifyName
+		}
+
+		u, err := client.ModifyUser(id, opts)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Updated user %v (id %v)\n", u.Username, u.ID)
+		return nil
+	},
+}
+
+func init() {
+	modifyCmd.Flags().BoolVar(&modifyAdmin, "admin", false, "grant or revoke admin access")
+	modifyCmd.Flags().BoolVar(&modifyExternal, "external", false, "mark the user as external or not")
+	modifyCmd.Flags().StringVar(&modifyEmail, "email", "", "new email address")
+	modifyCmd.Flags().StringVar(&modifyName, "name", "", "new display name")
+	rootCmd.AddCommand(modifyCmd)
+}
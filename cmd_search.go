@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+var searchActiveOnly bool
+
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search users by name, username or email",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := loadClient()
+		if err != nil {
+			return err
+		}
+		users, err := client.SearchUsers(args[0], searchActiveOnly)
+		if err != nil {
+			return err
+		}
+		sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+		if len(users) == 0 {
+			fmt.Println("No users found")
+			return nil
+		}
+		for _, u := range users {
+			fmt.Printf("%v \t%v \t%v \t%v\n", u.ID, u.Name, u.Username, u.Email)
+		}
+		return nil
+	},
+}
+
+func init() {
+	searchCmd.Flags().BoolVarP(&searchActiveOnly, "active", "a", true, "limit to active users")
+	rootCmd.AddCommand(searchCmd)
+}
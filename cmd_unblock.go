@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var unblockCmd = &cobra.Command{
+	Use:   "unblock <id>",
+	Short: "Unblock a user",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := userID(args)
+		if err != nil {
+			return err
+		}
+		client, err := loadClient()
+		if err != nil {
+			return err
+		}
+		if err := client.UnblockUser(id); err != nil {
+			return err
+		}
+		fmt.Printf("UserID %v has been unblocked\n", id)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(unblockCmd)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/danfinn/glu/internal/config"
+	"github.com/danfinn/glu/internal/gitlab"
+)
+
+// tokenScopesURL is appended to the instance URL to take the user straight
+// to a pre-filled "create a personal access token" form.
+const tokenScopesURL = "/-/profile/personal_access_tokens?name=glu&scopes=api,read_user,sudo"
+
+var configureCmd = &cobra.Command{
+	Use:   "configure",
+	Short: "Set the GitLab instance URL and access token glu uses",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigure()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configureCmd)
+}
+
+// runConfigure interactively collects a GitLab instance URL and access
+// token, verifies them against the instance, and persists them so every
+// other glu command can load a ready-to-use client via loadClient.
+func runConfigure() error {
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Print("GitLab instance URL: ")
+	rawURL, _ := reader.ReadString('\n')
+	rawURL = strings.TrimSpace(rawURL)
+
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("configure: %q is not a valid URL", rawURL)
+	}
+
+	fmt.Println("How would you like to authenticate?")
+	fmt.Println("  1) Paste a personal access token")
+	fmt.Println("  2) Generate one now in the browser")
+	fmt.Print("Choice [1]: ")
+	choice, _ := reader.ReadString('\n')
+	choice = strings.TrimSpace(choice)
+
+	if choice == "2" {
+		genURL := strings.TrimRight(rawURL, "/") + tokenScopesURL
+		fmt.Printf("Opening %s ...\n", genURL)
+		if err := openBrowser(genURL); err != nil {
+			fmt.Printf("Couldn't open a browser automatically, visit this URL: %s\n", genURL)
+		}
+	}
+
+	fmt.Print("Personal access token: ")
+	token, _ := reader.ReadString('\n')
+	token = strings.TrimSpace(token)
+
+	client, err := gitlab.New(rawURL, token, nil)
+	if err != nil {
+		return fmt.Errorf("configure: %w", err)
+	}
+	me, err := client.CurrentUser()
+	if err != nil {
+		return fmt.Errorf("configure: verifying token: %w", err)
+	}
+
+	cfg := &config.Config{BaseURL: rawURL, Token: token}
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("configure: %w", err)
+	}
+
+	path, _ := config.Path()
+	fmt.Printf("Authenticated as %s (@%s). Wrote config to %s\n", me.Name, me.Username, path)
+	return nil
+}
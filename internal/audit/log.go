@@ -0,0 +1,50 @@
+// Package audit persists an append-only record of the mutations glu's
+// `audit` commands make, so operators can see who was blocked, when, and
+// by which invocation.
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/danfinn/glu/internal/config"
+)
+
+// Entry is a single audit log line.
+type Entry struct {
+	Time     time.Time `json:"time"`
+	Action   string    `json:"action"`
+	UserID   int       `json:"user_id"`
+	Username string    `json:"username"`
+	Reason   string    `json:"reason"`
+}
+
+// Path returns the path to glu's audit log file.
+func Path() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "audit.jsonl"), nil
+}
+
+// Append writes e to the audit log as a single JSON line.
+func Append(e Entry) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("audit: opening %s: %w", path, err)
+	}
+	defer f.Close()
+
+	if err := json.NewEncoder(f).Encode(e); err != nil {
+		return fmt.Errorf("audit: writing entry: %w", err)
+	}
+	return nil
+}
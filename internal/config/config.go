@@ -0,0 +1,90 @@
+// Package config reads and writes glu's persisted settings: the GitLab
+// instance URL and the credentials used to talk to it.
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Config is the on-disk shape of $XDG_CONFIG_HOME/glu/config.json.
+type Config struct {
+	BaseURL string `json:"base_url"`
+	Token   string `json:"token"`
+
+	// RefreshToken, ClientID and ExpiresAt are only set when Token came from
+	// `glu login` (OAuth2) rather than `glu configure` (a static PAT).
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ClientID     string `json:"client_id,omitempty"`
+	ExpiresAt    int64  `json:"expires_at,omitempty"`
+}
+
+// HasRefreshToken reports whether c was populated by `glu login`, i.e.
+// whether its access token can be refreshed rather than just reused.
+func (c *Config) HasRefreshToken() bool {
+	return c.RefreshToken != ""
+}
+
+// Dir returns the directory glu stores its config file in, creating it if
+// it doesn't already exist.
+func Dir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("config: resolving config dir: %w", err)
+	}
+	dir := filepath.Join(base, "glu")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("config: creating %s: %w", dir, err)
+	}
+	return dir, nil
+}
+
+// Path returns the full path to glu's config file.
+func Path() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.json"), nil
+}
+
+// Load reads the config file. It returns a zero-value Config and no error
+// if the file doesn't exist yet, so callers can distinguish "not configured"
+// from a read failure.
+func Load() (*Config, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config: reading %s: %w", path, err)
+	}
+	var c Config
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("config: parsing %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// Save writes c to the config file with permissions restricted to the
+// owner, since it holds an access token.
+func (c *Config) Save() error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("config: encoding config: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("config: writing %s: %w", path, err)
+	}
+	return nil
+}
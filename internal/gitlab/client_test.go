@@ -0,0 +1,97 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newTestClient starts an httptest.Server driven by handler and returns a
+// Client pointed at it, exercising the same httpClient injection path real
+// callers use to fake GitLab's responses in tests.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c, err := New(srv.URL, "token", srv.Client())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return c
+}
+
+func TestCurrentUser(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/user" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": 1, "username": "ada"})
+	})
+
+	u, err := c.CurrentUser()
+	if err != nil {
+		t.Fatalf("CurrentUser: %v", err)
+	}
+	if u.Username != "ada" {
+		t.Fatalf("got username %q, want %q", u.Username, "ada")
+	}
+}
+
+func TestDeleteUserHardDelete(t *testing.T) {
+	var gotHardDelete string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotHardDelete = r.URL.Query().Get("hard_delete")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := c.DeleteUser(42, true); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+	if gotHardDelete != "true" {
+		t.Fatalf("hard_delete query param = %q, want %q", gotHardDelete, "true")
+	}
+}
+
+func TestListUsersKeysetPreservesFilter(t *testing.T) {
+	pages := [][]int{{1, 2}, {3, 4}}
+	var requests []*http.Request
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+
+		page := 0
+		if r.URL.Query().Get("id_after") == "2" {
+			page = 1
+		}
+
+		if page == 0 {
+			next := fmt.Sprintf("<%s/api/v4/users?active=true&id_after=2&pagination=keyset>; rel=\"next\"", "http://"+r.Host)
+			w.Header().Set("Link", next)
+		}
+
+		ids := pages[page]
+		users := make([]map[string]any, len(ids))
+		for i, id := range ids {
+			users[i] = map[string]any{"id": id}
+		}
+		json.NewEncoder(w).Encode(users)
+	})
+
+	users, err := c.GetUsers(true)
+	if err != nil {
+		t.Fatalf("GetUsers: %v", err)
+	}
+	if len(users) != 4 {
+		t.Fatalf("got %d users, want 4", len(users))
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("got %d requests, want 2", len(requests))
+	}
+	if requests[1].URL.Query().Get("active") != "true" {
+		t.Fatalf("second page lost the active filter: %s", requests[1].URL.RawQuery)
+	}
+}
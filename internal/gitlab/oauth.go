@@ -0,0 +1,279 @@
+package gitlab
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	gogitlab "github.com/xanzy/go-gitlab"
+)
+
+// OAuthTokens is the result of a successful login or refresh.
+type OAuthTokens struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int
+}
+
+// openBrowser is overridden by callers (the CLI's own browser.go) so this
+// package stays free of platform-specific exec code; it defaults to just
+// printing the URL if nothing overrides it.
+var openBrowser = func(u string) error {
+	fmt.Println("Open this URL to continue:", u)
+	return nil
+}
+
+// SetBrowserOpener lets callers plug in a real "open the user's browser"
+// implementation. Login falls back to printing the URL if none is set.
+func SetBrowserOpener(f func(url string) error) {
+	openBrowser = f
+}
+
+// Login runs GitLab's OAuth2 authorization-code flow with PKCE against
+// baseURL: it starts a localhost callback listener, opens the browser to
+// /oauth/authorize, exchanges the returned code at /oauth/token, and
+// returns the resulting tokens.
+func Login(baseURL, clientID string) (*OAuthTokens, error) {
+	verifier, challenge, err := generatePKCE()
+	if err != nil {
+		return nil, fmt.Errorf("gitlab: generating PKCE challenge: %w", err)
+	}
+	state, err := randomString(32)
+	if err != nil {
+		return nil, fmt.Errorf("gitlab: generating state: %w", err)
+	}
+
+	srv, listener, codeCh, errCh, err := listenForCallback(state)
+	if err != nil {
+		return nil, err
+	}
+	defer srv.Shutdown(context.Background())
+	redirectURI := fmt.Sprintf("http://%s/callback", listener.Addr().String())
+
+	authorizeURL := strings.TrimRight(baseURL, "/") + "/oauth/authorize?" + url.Values{
+		"response_type":         {"code"},
+		"client_id":             {clientID},
+		"redirect_uri":          {redirectURI},
+		"state":                 {state},
+		"code_challenge":        {challenge},
+		"code_challenge_method": {"S256"},
+		"scope":                 {"api"},
+	}.Encode()
+
+	if err := openBrowser(authorizeURL); err != nil {
+		fmt.Println("Open this URL to continue:", authorizeURL)
+	}
+
+	var code string
+	select {
+	case code = <-codeCh:
+	case err := <-errCh:
+		return nil, fmt.Errorf("gitlab: oauth callback: %w", err)
+	case <-time.After(5 * time.Minute):
+		return nil, fmt.Errorf("gitlab: timed out waiting for oauth callback")
+	}
+
+	return exchangeToken(baseURL, url.Values{
+		"grant_type":    {"authorization_code"},
+		"client_id":     {clientID},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"code_verifier": {verifier},
+	})
+}
+
+// RefreshAccessToken exchanges a refresh token for a new access token.
+func RefreshAccessToken(baseURL, clientID, refreshToken string) (*OAuthTokens, error) {
+	return exchangeToken(baseURL, url.Values{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {clientID},
+		"refresh_token": {refreshToken},
+	})
+}
+
+func exchangeToken(baseURL string, form url.Values) (*OAuthTokens, error) {
+	endpoint := strings.TrimRight(baseURL, "/") + "/oauth/token"
+	resp, err := http.PostForm(endpoint, form)
+	if err != nil {
+		return nil, fmt.Errorf("gitlab: requesting %s: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+		Error        string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("gitlab: decoding token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("gitlab: token endpoint returned %s: %s", resp.Status, body.Error)
+	}
+	return &OAuthTokens{
+		AccessToken:  body.AccessToken,
+		RefreshToken: body.RefreshToken,
+		ExpiresIn:    body.ExpiresIn,
+	}, nil
+}
+
+func generatePKCE() (verifier, challenge string, err error) {
+	verifier, err = randomString(64)
+	if err != nil {
+		return "", "", err
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	challenge = base64.RawURLEncoding.EncodeToString(sum[:])
+	return verifier, challenge, nil
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// listenForCallback starts a localhost HTTP server on an ephemeral port
+// that waits for GitLab's OAuth2 redirect, validates state, and delivers
+// the authorization code on codeCh. Only the caller's select (in Login)
+// reads from codeCh/errCh; listenForCallback itself never does, so there
+// is no race over who drains the single buffered value. The caller is
+// responsible for shutting srv down once it's done waiting.
+func listenForCallback(state string) (*http.Server, net.Listener, chan string, chan error, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("gitlab: starting local callback listener: %w", err)
+	}
+
+	codeCh := make(chan string, 1)
+	errCh := make(chan error, 1)
+	var once sync.Once
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if errMsg := q.Get("error"); errMsg != "" {
+			once.Do(func() { errCh <- fmt.Errorf("%s", errMsg) })
+			fmt.Fprintln(w, "Authorization failed, you can close this tab.")
+			return
+		}
+		if q.Get("state") != state {
+			once.Do(func() { errCh <- fmt.Errorf("state mismatch") })
+			http.Error(w, "state mismatch", http.StatusBadRequest)
+			return
+		}
+		once.Do(func() { codeCh <- q.Get("code") })
+		fmt.Fprintln(w, "Login complete, you can close this tab.")
+	})
+
+	srv := &http.Server{Handler: mux}
+	go srv.Serve(listener)
+
+	return srv, listener, codeCh, errCh, nil
+}
+
+// authTransport injects the current OAuth access token into every request
+// and transparently refreshes it on a 401, retrying the request once.
+type authTransport struct {
+	base     http.RoundTripper
+	baseURL  string
+	clientID string
+
+	mu           sync.Mutex
+	accessToken  string
+	refreshToken string
+	onRefresh    func(tokens *OAuthTokens)
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.doRoundTrip(req, t.currentToken())
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	resp.Body.Close()
+
+	tokens, refreshErr := t.refresh()
+	if refreshErr != nil {
+		return nil, fmt.Errorf("gitlab: refreshing access token after 401: %w", refreshErr)
+	}
+	return t.doRoundTrip(req, tokens.AccessToken)
+}
+
+func (t *authTransport) doRoundTrip(req *http.Request, token string) (*http.Response, error) {
+	clone := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("gitlab: rewinding request body for retry: %w", err)
+		}
+		clone.Body = body
+	}
+	clone.Header.Set("Authorization", "Bearer "+token)
+	return t.base.RoundTrip(clone)
+}
+
+func (t *authTransport) currentToken() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.accessToken
+}
+
+func (t *authTransport) refresh() (*OAuthTokens, error) {
+	t.mu.Lock()
+	refreshToken := t.refreshToken
+	t.mu.Unlock()
+	if refreshToken == "" {
+		return nil, fmt.Errorf("no refresh token available")
+	}
+
+	tokens, err := RefreshAccessToken(t.baseURL, t.clientID, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	t.accessToken = tokens.AccessToken
+	if tokens.RefreshToken != "" {
+		t.refreshToken = tokens.RefreshToken
+	}
+	t.mu.Unlock()
+
+	if t.onRefresh != nil {
+		t.onRefresh(tokens)
+	}
+	return tokens, nil
+}
+
+// NewOAuth builds a Client authenticated with an OAuth2 access token. When
+// refreshToken is non-empty, a 401 response transparently triggers a
+// refresh-token exchange and onRefresh (if set) is called with the new
+// tokens so the caller can persist them.
+func NewOAuth(baseURL, clientID, accessToken, refreshToken string, onRefresh func(tokens *OAuthTokens)) (*Client, error) {
+	transport := &authTransport{
+		base:         http.DefaultTransport,
+		baseURL:      baseURL,
+		clientID:     clientID,
+		accessToken:  accessToken,
+		refreshToken: refreshToken,
+		onRefresh:    onRefresh,
+	}
+	httpClient := &http.Client{Transport: transport}
+
+	gl, err := gogitlab.NewOAuthClient(accessToken, gogitlab.WithBaseURL(baseURL), gogitlab.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, fmt.Errorf("gitlab: building oauth client: %w", err)
+	}
+	return &Client{gl: gl, concurrency: defaultConcurrency, maxRetries: defaultMaxRetries}, nil
+}
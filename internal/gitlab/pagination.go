@@ -0,0 +1,205 @@
+package gitlab
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+// rateLimitLowWaterMark is how many requests must remain in GitLab's rate
+// limit bucket before workers pause until the bucket resets.
+const rateLimitLowWaterMark = 5
+
+// listUsers fetches every user matching opts, requesting GitLab's
+// recommended keyset pagination up front since it scales to large
+// instances better than offset pagination. If the first response's Link
+// header actually advertises a keyset continuation, it follows that link,
+// since keyset pages can't be fetched out of order. Older GitLab instances
+// that ignore the keyset request fall back to offset pagination, fanned
+// out across a worker pool once the first page reveals how many pages
+// there are.
+func (c *Client) listUsers(opts *gitlab.ListUsersOptions) ([]*gitlab.User, error) {
+	opts.PerPage = 100
+	opts.Pagination = "keyset"
+	opts.ListOptions.OrderBy = "id"
+	opts.ListOptions.Sort = "asc"
+
+	first, resp, err := c.requestUsersPage(opts)
+	if err != nil {
+		return nil, err
+	}
+	c.throttle(resp)
+
+	if isKeysetLink(resp.NextLink) {
+		return c.listUsersKeyset(opts, first, resp.NextLink)
+	}
+
+	// GitLab ignored the keyset request and paginated by offset instead;
+	// clear the pagination mode so the fallback below doesn't send a stale
+	// pagination=keyset param alongside page numbers, but keep order_by/sort
+	// so every page is drawn from the same ordering as page 1 - otherwise
+	// offset windows computed against different orderings could return
+	// duplicate or missing users.
+	opts.Pagination = ""
+
+	if resp.TotalPages <= 1 {
+		return first, nil
+	}
+	return c.listUsersOffsetConcurrent(opts, first, resp.TotalPages)
+}
+
+// listUsersOffsetConcurrent fetches pages 2..totalPages of an offset-paged
+// listing across c.concurrency workers.
+func (c *Client) listUsersOffsetConcurrent(opts *gitlab.ListUsersOptions, first []*gitlab.User, totalPages int) ([]*gitlab.User, error) {
+	concurrency := c.concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if remaining := totalPages - 1; concurrency > remaining {
+		concurrency = remaining
+	}
+
+	type pageResult struct {
+		page  int
+		users []*gitlab.User
+		err   error
+	}
+
+	pages := make(chan int)
+	results := make(chan pageResult, totalPages-1)
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for page := range pages {
+				pageOpts := *opts
+				pageOpts.Page = page
+				users, resp, err := c.requestUsersPage(&pageOpts)
+				c.throttle(resp)
+				results <- pageResult{page: page, users: users, err: err}
+			}
+		}()
+	}
+	go func() {
+		for p := 2; p <= totalPages; p++ {
+			pages <- p
+		}
+		close(pages)
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	byPage := make(map[int][]*gitlab.User, totalPages-1)
+	var firstErr error
+	for r := range results {
+		if r.err != nil {
+			if firstErr == nil {
+				firstErr = r.err
+			}
+			continue
+		}
+		byPage[r.page] = r.users
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	all := first
+	for p := 2; p <= totalPages; p++ {
+		all = append(all, byPage[p]...)
+	}
+	return all, nil
+}
+
+// listUsersKeyset continues a keyset-paginated listing from nextLink,
+// following the Link header's rel="next" URL one page at a time until it
+// stops advertising a next page. opts is the caller's original options
+// object, reused unmodified on every request (via
+// gitlab.WithKeysetPaginationParameters) so filters like Active survive
+// across pages.
+func (c *Client) listUsersKeyset(opts *gitlab.ListUsersOptions, first []*gitlab.User, nextLink string) ([]*gitlab.User, error) {
+	all := first
+
+	for nextLink != "" {
+		users, resp, err := c.requestUsersPage(opts, gitlab.WithKeysetPaginationParameters(nextLink))
+		if err != nil {
+			return nil, err
+		}
+		c.throttle(resp)
+		all = append(all, users...)
+		nextLink = resp.NextLink
+	}
+	return all, nil
+}
+
+// requestUsersPage issues a single ListUsers call, retrying transient
+// (429/5xx) failures with exponential backoff and jitter up to
+// c.maxRetries times. reqOpts are passed straight through to the
+// underlying client, used by listUsersKeyset to apply keyset cursor
+// parameters without rebuilding opts.
+func (c *Client) requestUsersPage(opts *gitlab.ListUsersOptions, reqOpts ...gitlab.RequestOptionFunc) ([]*gitlab.User, *gitlab.Response, error) {
+	maxRetries := c.maxRetries
+	if maxRetries < 1 {
+		maxRetries = 1
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		users, resp, err := c.gl.Users.ListUsers(opts, reqOpts...)
+		if err == nil {
+			return users, resp, nil
+		}
+		lastErr = err
+		if resp == nil || !retryableStatus(resp.StatusCode) || attempt == maxRetries-1 {
+			return nil, resp, fmt.Errorf("gitlab: listing users: %w", err)
+		}
+		time.Sleep(backoff(attempt))
+	}
+	return nil, nil, fmt.Errorf("gitlab: listing users: giving up after %d attempts: %w", maxRetries, lastErr)
+}
+
+func retryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= 500
+}
+
+// backoff returns an exponentially increasing delay with full jitter,
+// starting around 200ms.
+func backoff(attempt int) time.Duration {
+	base := time.Duration(1<<uint(attempt)) * 200 * time.Millisecond
+	return time.Duration(rand.Int63n(int64(base) + 1))
+}
+
+// throttle pauses the caller until GitLab's rate limit window resets if
+// the response reports the bucket is nearly exhausted.
+func (c *Client) throttle(resp *gitlab.Response) {
+	if resp == nil || resp.Response == nil {
+		return
+	}
+	remaining, err := strconv.Atoi(resp.Header.Get("RateLimit-Remaining"))
+	if err != nil || remaining > rateLimitLowWaterMark {
+		return
+	}
+	resetAt, err := strconv.ParseInt(resp.Header.Get("RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return
+	}
+	if wait := time.Until(time.Unix(resetAt, 0)); wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+// isKeysetLink reports whether a rel="next" Link header URL is a keyset
+// (id_after-based) continuation rather than a plain offset/page link.
+func isKeysetLink(nextLink string) bool {
+	return nextLink != "" && strings.Contains(nextLink, "id_after=")
+}
@@ -0,0 +1,95 @@
+package userio
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ReadCSV parses r as CSV, treating the first row as column headers, and
+// returns one map per data row keyed by header name.
+func ReadCSV(r io.Reader) ([]map[string]string, error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("userio: reading csv header: %w", err)
+	}
+
+	var rows []map[string]string
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("userio: reading csv row: %w", err)
+		}
+		row := make(map[string]string, len(header))
+		for i, h := range header {
+			if i < len(record) {
+				row[h] = record[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// ReadJSON parses r as a JSON array of flat objects and returns one map per
+// element, stringifying any non-string values.
+func ReadJSON(r io.Reader) ([]map[string]string, error) {
+	var raw []map[string]interface{}
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("userio: reading json: %w", err)
+	}
+	rows := make([]map[string]string, len(raw))
+	for i, rec := range raw {
+		row := make(map[string]string, len(rec))
+		for k, v := range rec {
+			row[k] = fmt.Sprintf("%v", v)
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+// LoadMapping reads a JSON object mapping source column headers (e.g. from
+// an LDAP/HR export: "mail", "login", "display_name") to the canonical
+// field names in Fields (e.g. "email", "username", "name").
+func LoadMapping(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("userio: reading mapping file %s: %w", path, err)
+	}
+	var mapping map[string]string
+	if err := json.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("userio: parsing mapping file %s: %w", path, err)
+	}
+	return mapping, nil
+}
+
+// ApplyMapping renames keys in each row according to mapping (source ->
+// canonical), leaving unmapped keys untouched.
+func ApplyMapping(rows []map[string]string, mapping map[string]string) []map[string]string {
+	if len(mapping) == 0 {
+		return rows
+	}
+	mapped := make([]map[string]string, len(rows))
+	for i, row := range rows {
+		out := make(map[string]string, len(row))
+		for k, v := range row {
+			if canonical, ok := mapping[k]; ok {
+				out[canonical] = v
+				continue
+			}
+			out[k] = v
+		}
+		mapped[i] = out
+	}
+	return mapped
+}
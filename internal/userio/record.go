@@ -0,0 +1,69 @@
+// Package userio converts between GitLab users and the flat CSV/JSON rows
+// glu's export and import commands read and write.
+package userio
+
+import (
+	"strconv"
+
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+// Fields lists every column export/import understands, in the default
+// output order. Callers may select a subset via --fields.
+var Fields = []string{"id", "name", "username", "email", "state", "is_admin", "external"}
+
+// Record is the flat view of a GitLab user used for export/import.
+type Record struct {
+	ID       int
+	Name     string
+	Username string
+	Email    string
+	State    string
+	IsAdmin  bool
+	External bool
+}
+
+// FromUser builds a Record from a *gitlab.User.
+func FromUser(u *gitlab.User) Record {
+	return Record{
+		ID:       u.ID,
+		Name:     u.Name,
+		Username: u.Username,
+		Email:    u.Email,
+		State:    u.State,
+		IsAdmin:  u.IsAdmin,
+		External: u.External,
+	}
+}
+
+// Field returns the string representation of the named column, or "" for
+// an unknown field.
+func (r Record) Field(name string) string {
+	switch name {
+	case "id":
+		return strconv.Itoa(r.ID)
+	case "name":
+		return r.Name
+	case "username":
+		return r.Username
+	case "email":
+		return r.Email
+	case "state":
+		return r.State
+	case "is_admin":
+		return strconv.FormatBool(r.IsAdmin)
+	case "external":
+		return strconv.FormatBool(r.External)
+	default:
+		return ""
+	}
+}
+
+// Row renders r as a slice of strings in the order given by fields.
+func (r Record) Row(fields []string) []string {
+	row := make([]string, len(fields))
+	for i, f := range fields {
+		row[i] = r.Field(f)
+	}
+	return row
+}
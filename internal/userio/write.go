@@ -0,0 +1,41 @@
+package userio
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// WriteCSV writes records to w as CSV with a header row listing fields.
+func WriteCSV(w io.Writer, records []Record, fields []string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(fields); err != nil {
+		return fmt.Errorf("userio: writing csv header: %w", err)
+	}
+	for _, r := range records {
+		if err := cw.Write(r.Row(fields)); err != nil {
+			return fmt.Errorf("userio: writing csv row: %w", err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes records to w as a JSON array of objects keyed by fields.
+func WriteJSON(w io.Writer, records []Record, fields []string) error {
+	rows := make([]map[string]string, len(records))
+	for i, r := range records {
+		row := make(map[string]string, len(fields))
+		for _, f := range fields {
+			row[f] = r.Field(f)
+		}
+		rows[i] = row
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(rows); err != nil {
+		return fmt.Errorf("userio: writing json: %w", err)
+	}
+	return nil
+}
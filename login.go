@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/danfinn/glu/internal/config"
+	"github.com/danfinn/glu/internal/gitlab"
+)
+
+var loginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Authenticate with GitLab via OAuth2 instead of a static token",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clientID, _ := cmd.Flags().GetString("client-id")
+		return runLogin(flagBaseURL, clientID)
+	},
+}
+
+func init() {
+	gitlab.SetBrowserOpener(openBrowser)
+	loginCmd.Flags().String("client-id", "", "OAuth2 application ID (defaults to $GLU_CLIENT_ID)")
+	rootCmd.AddCommand(loginCmd)
+}
+
+// runLogin performs GitLab's OAuth2 authorization-code-with-PKCE flow
+// against baseURL and persists the resulting access/refresh tokens, as an
+// alternative to pasting a long-lived PAT via `glu configure`.
+func runLogin(baseURL, clientID string) error {
+	if clientID == "" {
+		clientID = os.Getenv("GLU_CLIENT_ID")
+	}
+	if clientID == "" {
+		return fmt.Errorf("login: no OAuth2 client id; pass --client-id or set GLU_CLIENT_ID")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if baseURL == "" {
+		baseURL = cfg.BaseURL
+	}
+	if baseURL == "" {
+		return fmt.Errorf("login: no GitLab instance URL; pass --url or run `glu configure` first")
+	}
+
+	tokens, err := gitlab.Login(baseURL, clientID)
+	if err != nil {
+		return fmt.Errorf("login: %w", err)
+	}
+
+	cfg.BaseURL = baseURL
+	cfg.ClientID = clientID
+	cfg.Token = tokens.AccessToken
+	cfg.RefreshToken = tokens.RefreshToken
+	if tokens.ExpiresIn > 0 {
+		cfg.ExpiresAt = time.Now().Add(time.Duration(tokens.ExpiresIn) * time.Second).Unix()
+	}
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("login: %w", err)
+	}
+
+	path, _ := config.Path()
+	fmt.Println("Logged in. Tokens saved to", path)
+	return nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/danfinn/glu/internal/config"
+	"github.com/danfinn/glu/internal/gitlab"
+)
+
+var (
+	flagToken       string
+	flagBaseURL     string
+	flagConcurrency int
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "glu",
+	Short: "Manage GitLab users from the command line",
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVarP(&flagToken, "token", "t", "", "Gitlab access token (overrides config)")
+	rootCmd.PersistentFlags().StringVarP(&flagBaseURL, "url", "u", "", "Gitlab base URL (overrides config)")
+	rootCmd.PersistentFlags().IntVar(&flagConcurrency, "concurrency", 8, "number of workers used to fan out paginated user listings")
+}
+
+// loadClient builds a gitlab.Client from the persisted config, falling
+// back to the --token/--url flags when set. If the config came from
+// `glu login` rather than `glu configure`, the client transparently
+// refreshes its access token on a 401 and persists the new tokens. Run
+// `glu configure` or `glu login` first if neither source has credentials.
+func loadClient() (*gitlab.Client, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	token := flagToken
+	if token == "" {
+		token = cfg.Token
+	}
+	baseURL := flagBaseURL
+	if baseURL == "" {
+		baseURL = cfg.BaseURL
+	}
+	if token == "" || baseURL == "" {
+		return nil, fmt.Errorf("not configured: run `glu configure` or `glu login` first")
+	}
+
+	var client *gitlab.Client
+	if token == cfg.Token && cfg.HasRefreshToken() {
+		client, err = gitlab.NewOAuth(baseURL, cfg.ClientID, cfg.Token, cfg.RefreshToken, func(tokens *gitlab.OAuthTokens) {
+			cfg.Token = tokens.AccessToken
+			if tokens.RefreshToken != "" {
+				cfg.RefreshToken = tokens.RefreshToken
+			}
+			cfg.Save()
+		})
+	} else {
+		client, err = gitlab.New(baseURL, token, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	client.SetConcurrency(flagConcurrency)
+	return client, nil
+}
+
+// userID parses the first positional argument as a GitLab user ID.
+func userID(args []string) (int, error) {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a valid user ID", args[0])
+	}
+	return id, nil
+}
+
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		// cobra has already printed the error; just set the exit code.
+		os.Exit(1)
+	}
+}